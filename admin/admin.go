@@ -0,0 +1,51 @@
+// Package admin exposes every command registered on a
+// command.SuperStringArgsDispatcher as a searchable HTML index, with a
+// generated htmlform for each command that invokes it and renders the
+// result inline.
+package admin
+
+import (
+	"sort"
+
+	command "github.com/ungerik/go-command"
+)
+
+// Entry describes one command of the index.
+type Entry struct {
+	SuperCommand string
+	Command      string
+	Description  string
+	Args         command.Args
+	commandFunc  interface{}
+}
+
+// List returns every command registered on disp as an Entry, sorted by
+// super command and then by command name.
+func List(disp *command.SuperStringArgsDispatcher) []Entry {
+	var entries []Entry
+	disp.WalkCommands(func(cmd command.CommandInfo) {
+		entries = append(entries, Entry{
+			SuperCommand: cmd.SuperCommand,
+			Command:      cmd.Command,
+			Description:  cmd.Description,
+			Args:         cmd.Args,
+			commandFunc:  cmd.CommandFunc,
+		})
+	})
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].SuperCommand == entries[j].SuperCommand {
+			return entries[i].Command < entries[j].Command
+		}
+		return entries[i].SuperCommand < entries[j].SuperCommand
+	})
+	return entries
+}
+
+// Title returns the "super command" display name, falling back to just
+// the command name for commands without a super command.
+func (e Entry) Title() string {
+	if e.SuperCommand == command.Default {
+		return e.Command
+	}
+	return e.SuperCommand + " " + e.Command
+}
@@ -0,0 +1,90 @@
+package admin
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"net/http"
+
+	command "github.com/ungerik/go-command"
+	"github.com/ungerik/go-command/bodybind"
+	"github.com/ungerik/go-command/htmlform"
+)
+
+var formTemplate = template.Must(template.New("admin-form").Parse(htmlform.FormTemplate))
+
+// FormHandler renders an htmlform for entry on GET requests, and on POST
+// invokes the command via entry's commandFunc and renders the result
+// inline below the form.
+func FormHandler(entry Entry) http.HandlerFunc {
+	cmdFunc := command.MustGetStringMapAnyArgsResultValuesFunc(entry.commandFunc, entry.Args)
+
+	return func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+		if request.Method != http.MethodPost {
+			data := htmlform.Data{
+				Title:            entry.Title(),
+				Fields:           htmlform.FieldsFromArgs(entry.Args, nil),
+				SubmitButtonText: "Run",
+			}
+			formTemplate.Execute(writer, data)
+			return
+		}
+
+		if err := request.ParseMultipartForm(32 << 20); err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		vars := make(map[string]interface{})
+		for _, arg := range entry.Args.Args() {
+			if arg.Type == "[]byte" || arg.Type == "file" {
+				content, err := readMultipartFile(request, arg.Name)
+				if err != nil {
+					http.Error(writer, err.Error(), http.StatusBadRequest)
+					return
+				}
+				vars[arg.Name] = content
+				continue
+			}
+			vars[arg.Name] = request.FormValue(arg.Name)
+		}
+
+		results, err := cmdFunc(request.Context(), vars)
+
+		data := htmlform.Data{
+			Title:            entry.Title(),
+			Fields:           htmlform.FieldsFromArgs(entry.Args, bodybind.StringifyVars(vars)),
+			SubmitButtonText: "Run",
+		}
+		formTemplate.Execute(writer, data)
+
+		fmt.Fprintf(writer, "<h2>Result</h2>\n")
+		if err != nil {
+			fmt.Fprintf(writer, "<pre style=\"color:red\">%s</pre>\n", template.HTMLEscapeString(err.Error()))
+			return
+		}
+		fmt.Fprintf(writer, "<pre>%s</pre>\n", template.HTMLEscapeString(fmt.Sprintf("%+v", results)))
+	}
+}
+
+// readMultipartFile returns the content of the uploaded file part named
+// name, or nil if none was submitted. request.FormValue cannot see
+// uploaded files: it only reads request.Form, which multipart parsing
+// populates with non-file values, leaving request.MultipartForm.File as
+// the only place the bytes actually live.
+func readMultipartFile(request *http.Request, name string) ([]byte, error) {
+	if request.MultipartForm == nil {
+		return nil, nil
+	}
+	headers := request.MultipartForm.File[name]
+	if len(headers) == 0 {
+		return nil, nil
+	}
+	file, err := headers[0].Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return io.ReadAll(file)
+}
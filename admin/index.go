@@ -0,0 +1,81 @@
+package admin
+
+import (
+	"html/template"
+	"net/http"
+
+	command "github.com/ungerik/go-command"
+)
+
+var indexTemplate = template.Must(template.New("admin-index").Parse(`
+<!DOCTYPE html>
+<html lang="en">
+<head>
+	<meta charset="utf-8"/>
+	<title>Commands</title>
+	<style>
+		* { font-family: "Lucida Console", Monaco, monospace; }
+		table { border-collapse: collapse; width: 100%; }
+		td, th { border: 1px solid #ccc; padding: 4px 8px; text-align: left; }
+		#filter { width: 100%; padding: 6px; margin-bottom: 10px; box-sizing: border-box; }
+	</style>
+	<script>
+		function filterRows() {
+			var q = document.getElementById("filter").value.toLowerCase();
+			document.querySelectorAll("tbody tr").forEach(function(row) {
+				row.style.display = row.dataset.search.indexOf(q) >= 0 ? "" : "none";
+			});
+		}
+	</script>
+</head>
+<body>
+<h1>Commands</h1>
+<input id="filter" type="text" placeholder="Filter commands..." onkeyup="filterRows()"/>
+<table>
+	<thead><tr><th>Super</th><th>Command</th><th>Description</th></tr></thead>
+	<tbody>
+	{{range .}}
+		<tr data-search="{{.SuperCommand}} {{.Command}} {{.Description}}">
+			<td>{{.SuperCommand}}</td>
+			<td><a href="{{.Path}}">{{.Command}}</a></td>
+			<td>{{.Description}}</td>
+		</tr>
+	{{end}}
+	</tbody>
+</table>
+</body>
+</html>
+`))
+
+type indexRow struct {
+	Entry
+	Path string
+}
+
+// IndexHandler renders an HTML index of every command registered on
+// disp, grouped by super command with a client-side filter box. Each
+// command links to basePath+"/"+superCommand+"/"+command, or just
+// basePath+"/"+command for commands without a super command, which is
+// expected to be served by FormHandler.
+func IndexHandler(disp *command.SuperStringArgsDispatcher, basePath string) http.HandlerFunc {
+	entries := List(disp)
+	rows := make([]indexRow, len(entries))
+	for i, entry := range entries {
+		rows[i] = indexRow{Entry: entry, Path: commandPath(basePath, entry)}
+	}
+
+	return func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set("Content-Type", "text/html; charset=utf-8")
+		indexTemplate.Execute(writer, rows)
+	}
+}
+
+// commandPath mirrors Entry.Title's handling of command.Default so a
+// command with no super command gets basePath+"/"+command instead of a
+// malformed basePath+"//"+command.
+func commandPath(basePath string, entry Entry) string {
+	if entry.SuperCommand == command.Default {
+		return basePath + "/" + entry.Command
+	}
+	return basePath + "/" + entry.SuperCommand + "/" + entry.Command
+}
@@ -0,0 +1,125 @@
+// Package bodybind decodes structured HTTP request bodies into the
+// map[string]interface{} vars expected by
+// command.MustGetStringMapAnyArgsResultValuesFunc, replacing the
+// fmt.Sprint-based flattening that gorillamux.MapJSONBodyFieldsAsVars
+// and gorillamux.JSONBodyFieldsAsVars apply, which corrupts nested
+// objects, arrays, numbers-with-precision and null.
+package bodybind
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"sync"
+
+	command "github.com/ungerik/go-command"
+)
+
+// Codec decodes an HTTP request body into a flat map of field name to
+// decoded value.
+type Codec interface {
+	Decode(request *http.Request) (map[string]interface{}, error)
+}
+
+// CodecFunc implements Codec with a plain function.
+type CodecFunc func(request *http.Request) (map[string]interface{}, error)
+
+func (f CodecFunc) Decode(request *http.Request) (map[string]interface{}, error) {
+	return f(request)
+}
+
+var (
+	registryMtx sync.RWMutex
+	registry    = map[string]Codec{
+		"application/json":                  CodecFunc(decodeJSON),
+		"application/x-www-form-urlencoded": CodecFunc(decodeForm),
+		"multipart/form-data":               CodecFunc(decodeMultipart),
+		"application/yaml":                  CodecFunc(decodeYAML),
+		"text/yaml":                         CodecFunc(decodeYAML),
+	}
+)
+
+// Register adds or replaces the Codec used for contentType, e.g. to
+// plug in a protobuf Codec built from a reflected message descriptor.
+func Register(contentType string, codec Codec) {
+	registryMtx.Lock()
+	defer registryMtx.Unlock()
+	registry[contentType] = codec
+}
+
+func codecFor(request *http.Request) (Codec, error) {
+	contentType, _, err := mime.ParseMediaType(request.Header.Get("Content-Type"))
+	if err != nil {
+		contentType = "application/json" // matches the previous JSONBodyFieldsAsVars default
+	}
+	registryMtx.RLock()
+	codec, ok := registry[contentType]
+	registryMtx.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("bodybind: no codec registered for content type %q", contentType)
+	}
+	return codec, nil
+}
+
+// Bind decodes request's body with the Codec registered for its
+// Content-Type and coerces the result into vars ready for
+// command.MustGetStringMapAnyArgsResultValuesFunc: a field whose target
+// Arg.Type is "string" is re-serialized as a JSON string if it decoded
+// to a nested object or array, every other field is passed through
+// as-is so the command reflection layer can assign it directly without
+// a string round-trip.
+func Bind(args command.Args, request *http.Request) (map[string]interface{}, error) {
+	codec, err := codecFor(request)
+	if err != nil {
+		return nil, err
+	}
+	decoded, err := codec.Decode(request)
+	if err != nil {
+		return nil, err
+	}
+	return coerce(args, decoded), nil
+}
+
+func coerce(args command.Args, decoded map[string]interface{}) map[string]interface{} {
+	stringArgs := make(map[string]bool, len(args.Args()))
+	for _, arg := range args.Args() {
+		if arg.Type == "string" {
+			stringArgs[arg.Name] = true
+		}
+	}
+
+	vars := make(map[string]interface{}, len(decoded))
+	for name, value := range decoded {
+		switch value.(type) {
+		case map[string]interface{}, []interface{}, []string:
+			if stringArgs[name] {
+				value = marshalOrOriginal(value)
+			}
+		}
+		vars[name] = value
+	}
+	return vars
+}
+
+func marshalOrOriginal(value interface{}) interface{} {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+	return string(b)
+}
+
+// StringifyVars renders vars with fmt.Sprint, for callers such as
+// gorillamux.ResultsWriter that still expect a map[string]string, e.g.
+// to echo back the request for logging or error messages. Prefer
+// passing vars returned by Bind directly to
+// command.MustGetStringMapAnyArgsResultValuesFunc wherever possible,
+// since this loses the precision Bind preserves.
+func StringifyVars(vars map[string]interface{}) map[string]string {
+	strs := make(map[string]string, len(vars))
+	for name, value := range vars {
+		strs[name] = fmt.Sprint(value)
+	}
+	return strs
+}
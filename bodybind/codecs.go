@@ -0,0 +1,82 @@
+package bodybind
+
+import (
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+
+	"gopkg.in/yaml.v3"
+)
+
+func decodeJSON(request *http.Request) (map[string]interface{}, error) {
+	defer request.Body.Close()
+	fields := make(map[string]interface{})
+	if err := json.NewDecoder(request.Body).Decode(&fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+func decodeYAML(request *http.Request) (map[string]interface{}, error) {
+	defer request.Body.Close()
+	fields := make(map[string]interface{})
+	if err := yaml.NewDecoder(request.Body).Decode(&fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+func decodeForm(request *http.Request) (map[string]interface{}, error) {
+	if err := request.ParseForm(); err != nil {
+		return nil, err
+	}
+	fields := make(map[string]interface{}, len(request.PostForm))
+	for name, values := range request.PostForm {
+		if len(values) == 1 {
+			fields[name] = values[0]
+		} else {
+			fields[name] = values
+		}
+	}
+	return fields, nil
+}
+
+func decodeMultipart(request *http.Request) (map[string]interface{}, error) {
+	if err := request.ParseMultipartForm(32 << 20); err != nil {
+		return nil, err
+	}
+	fields := make(map[string]interface{}, len(request.MultipartForm.Value)+len(request.MultipartForm.File))
+	for name, values := range request.MultipartForm.Value {
+		if len(values) == 1 {
+			fields[name] = values[0]
+		} else {
+			fields[name] = values
+		}
+	}
+	for name, headers := range request.MultipartForm.File {
+		if len(headers) == 0 {
+			continue
+		}
+		content, err := readFilePart(headers[0])
+		if err != nil {
+			return nil, err
+		}
+		fields[name] = content
+	}
+	return fields, nil
+}
+
+// readFilePart returns the content of an uploaded multipart file part,
+// so a command arg expecting file bytes receives []byte directly
+// instead of the *multipart.FileHeader pointer, which the reflection in
+// command.MustGetStringMapAnyArgsResultValuesFunc has no way to turn
+// into []byte.
+func readFilePart(header *multipart.FileHeader) ([]byte, error) {
+	file, err := header.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return io.ReadAll(file)
+}
@@ -0,0 +1,78 @@
+package command
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DeadlineTimer models a resettable deadline using the pattern from
+// gVisor's netstack gonet deadlineTimer: Cancelled returns a channel
+// that is closed once the deadline elapses, and SetDeadline atomically
+// swaps in a fresh channel whenever it reschedules a deadline that has
+// already fired. time.Timer.Stop returning false tells SetDeadline that
+// the old channel is already closed, so a new one is allocated before
+// the next timer is armed — this lets any number of goroutines waiting
+// on an old Cancelled() channel observe the close exactly once, while
+// goroutines calling Cancelled() after the reset wait on the new
+// channel instead.
+type DeadlineTimer struct {
+	mtx      sync.Mutex
+	timer    *time.Timer
+	cancelCh chan struct{}
+}
+
+// NewDeadlineTimer returns a DeadlineTimer with no deadline set; its
+// Cancelled channel stays open until SetDeadline is called.
+func NewDeadlineTimer() *DeadlineTimer {
+	return &DeadlineTimer{cancelCh: make(chan struct{})}
+}
+
+// SetDeadline arranges for the channel returned by Cancelled to be
+// closed at t. A zero t cancels any previously set deadline without
+// closing the channel. Calling SetDeadline again before a previous
+// deadline elapses reschedules it.
+func (d *DeadlineTimer) SetDeadline(t time.Time) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	if d.timer != nil && !d.timer.Stop() {
+		// The timer already fired and closed cancelCh, so later
+		// waiters need a fresh channel to wait on.
+		d.cancelCh = make(chan struct{})
+	}
+	d.timer = nil
+
+	if t.IsZero() {
+		return
+	}
+
+	cancelCh := d.cancelCh
+	d.timer = time.AfterFunc(time.Until(t), func() {
+		close(cancelCh)
+	})
+}
+
+// Cancelled returns the channel that is closed once the deadline current
+// at the time of the call elapses.
+func (d *DeadlineTimer) Cancelled() <-chan struct{} {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	return d.cancelCh
+}
+
+type deadlineTimerContextKey struct{}
+
+// WithDeadlineTimer returns a context carrying dt, so that a cmdFunc can
+// poll dt.Cancelled() for cancellation independently of ctx.Done(),
+// without racing a deadline reset performed by the caller.
+func WithDeadlineTimer(ctx context.Context, dt *DeadlineTimer) context.Context {
+	return context.WithValue(ctx, deadlineTimerContextKey{}, dt)
+}
+
+// DeadlineTimerFromContext returns the DeadlineTimer stored by
+// WithDeadlineTimer, or nil if none was set.
+func DeadlineTimerFromContext(ctx context.Context) *DeadlineTimer {
+	dt, _ := ctx.Value(deadlineTimerContextKey{}).(*DeadlineTimer)
+	return dt
+}
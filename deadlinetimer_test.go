@@ -0,0 +1,97 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestDeadlineTimerNoDeadline(t *testing.T) {
+	dt := NewDeadlineTimer()
+	select {
+	case <-dt.Cancelled():
+		t.Fatal("Cancelled channel closed without a deadline set")
+	case <-time.After(10 * time.Millisecond):
+	}
+}
+
+func TestDeadlineTimerFires(t *testing.T) {
+	dt := NewDeadlineTimer()
+	dt.SetDeadline(time.Now().Add(10 * time.Millisecond))
+
+	select {
+	case <-dt.Cancelled():
+	case <-time.After(time.Second):
+		t.Fatal("Cancelled channel did not close by the deadline")
+	}
+}
+
+func TestDeadlineTimerResetBeforeFiring(t *testing.T) {
+	dt := NewDeadlineTimer()
+	dt.SetDeadline(time.Now().Add(time.Hour))
+	first := dt.Cancelled()
+
+	dt.SetDeadline(time.Now().Add(10 * time.Millisecond))
+	second := dt.Cancelled()
+
+	select {
+	case <-first:
+	case <-time.After(time.Second):
+		t.Fatal("channel returned before reset never closes")
+	}
+	select {
+	case <-second:
+	case <-time.After(time.Second):
+		t.Fatal("channel returned after reset did not close by the new deadline")
+	}
+}
+
+// TestDeadlineTimerResetAfterFiring exercises the path where SetDeadline
+// is called again after the previous deadline already fired: Stop
+// returns false, so a fresh channel must be allocated instead of
+// reusing the already-closed one.
+func TestDeadlineTimerResetAfterFiring(t *testing.T) {
+	dt := NewDeadlineTimer()
+	dt.SetDeadline(time.Now().Add(5 * time.Millisecond))
+	<-dt.Cancelled()
+
+	dt.SetDeadline(time.Now().Add(time.Hour))
+	select {
+	case <-dt.Cancelled():
+		t.Fatal("Cancelled channel closed immediately after a fresh deadline was set")
+	case <-time.After(20 * time.Millisecond):
+	}
+}
+
+func TestDeadlineTimerConcurrentWaiters(t *testing.T) {
+	dt := NewDeadlineTimer()
+	dt.SetDeadline(time.Now().Add(10 * time.Millisecond))
+
+	const waiters = 8
+	done := make(chan struct{}, waiters)
+	for i := 0; i < waiters; i++ {
+		go func() {
+			<-dt.Cancelled()
+			done <- struct{}{}
+		}()
+	}
+	for i := 0; i < waiters; i++ {
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("not every waiter observed the deadline close")
+		}
+	}
+}
+
+func TestDeadlineTimerFromContext(t *testing.T) {
+	if dt := DeadlineTimerFromContext(context.Background()); dt != nil {
+		t.Fatalf("expected nil for a context without a DeadlineTimer, got %v", dt)
+	}
+
+	dt := NewDeadlineTimer()
+	ctx := WithDeadlineTimer(context.Background(), dt)
+	if got := DeadlineTimerFromContext(ctx); got != dt {
+		t.Fatalf("DeadlineTimerFromContext returned %v, want %v", got, dt)
+	}
+}
@@ -0,0 +1,45 @@
+package gorillamux
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ungerik/go-command"
+	"github.com/ungerik/go-command/bodybind"
+)
+
+// CommandHandlerBodyBound behaves like CommandHandler, but populates the
+// command's arguments from a structured request body via bodybind.Bind
+// instead of requiring the caller to pre-flatten it with
+// MapJSONBodyFieldsAsVars or JSONBodyFieldsAsVars, so JSON, YAML, form
+// and multipart bodies can supply nested objects, arrays and typed
+// scalars without a lossy fmt.Sprint round-trip. Mux path variables take
+// precedence over same-named body fields.
+func CommandHandlerBodyBound(commandFunc interface{}, args command.Args, resultsWriter ResultsWriter, opts ...HandlerOptions) http.HandlerFunc {
+	cmdFunc := command.MustGetStringMapAnyArgsResultValuesFunc(commandFunc, args)
+	options := firstOptionsOrDefault(opts)
+
+	return options.wrap(func(writer http.ResponseWriter, request *http.Request) {
+		vars, err := bodybind.Bind(args, request)
+		if err != nil {
+			handleErr(err, writer, request, options.ErrHandlers)
+			return
+		}
+		for name, value := range mux.Vars(request) {
+			vars[name] = value
+		}
+		RecordArgs(request.Context(), bodybind.StringifyVars(vars))
+
+		ctx, cancel := options.withDeadline(request.Context(), request)
+		defer cancel()
+
+		resultVals, err := cmdFunc(ctx, vars)
+		err = translateDeadlineErr(ctx, err)
+
+		if resultsWriter != nil {
+			err = resultsWriter.WriteResults(args, bodybind.StringifyVars(vars), resultVals, err, writer, request)
+		}
+		handleErr(err, writer, request, options.ErrHandlers)
+	})
+}
@@ -0,0 +1,7 @@
+package gorillamux
+
+import "github.com/ungerik/go-command/middleware/recovermw"
+
+func init() {
+	DefaultMiddlewares = []Middleware{recovermw.Middleware}
+}
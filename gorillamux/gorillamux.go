@@ -1,7 +1,9 @@
 package gorillamux
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -13,37 +15,32 @@ import (
 	"github.com/ungerik/go-httpx/httperr"
 )
 
-func CommandHandler(commandFunc interface{}, args command.Args, resultsWriter ResultsWriter, errHandlers ...httperr.Handler) http.HandlerFunc {
+func CommandHandler(commandFunc interface{}, args command.Args, resultsWriter ResultsWriter, opts ...HandlerOptions) http.HandlerFunc {
 	cmdFunc := command.MustGetStringMapArgsResultValuesFunc(commandFunc, args)
+	options := firstOptionsOrDefault(opts)
 
-	return func(writer http.ResponseWriter, request *http.Request) {
-		if CatchPanics {
-			defer func() {
-				handleErr(httperr.AsError(recover()), writer, request, errHandlers)
-			}()
-		}
-
+	return options.wrap(func(writer http.ResponseWriter, request *http.Request) {
 		vars := mux.Vars(request)
+		RecordArgs(request.Context(), vars)
+
+		ctx, cancel := options.withDeadline(request.Context(), request)
+		defer cancel()
 
-		resultVals, err := cmdFunc(request.Context(), vars)
+		resultVals, err := cmdFunc(ctx, vars)
+		err = translateDeadlineErr(ctx, err)
 
 		if resultsWriter != nil {
 			err = resultsWriter.WriteResults(args, vars, resultVals, err, writer, request)
 		}
-		handleErr(err, writer, request, errHandlers)
-	}
+		handleErr(err, writer, request, options.ErrHandlers)
+	})
 }
 
-func CommandHandlerWithQueryParams(commandFunc interface{}, args command.Args, resultsWriter ResultsWriter, errHandlers ...httperr.Handler) http.HandlerFunc {
+func CommandHandlerWithQueryParams(commandFunc interface{}, args command.Args, resultsWriter ResultsWriter, opts ...HandlerOptions) http.HandlerFunc {
 	cmdFunc := command.MustGetStringMapArgsResultValuesFunc(commandFunc, args)
+	options := firstOptionsOrDefault(opts)
 
-	return func(writer http.ResponseWriter, request *http.Request) {
-		if CatchPanics {
-			defer func() {
-				handleErr(httperr.AsError(recover()), writer, request, errHandlers)
-			}()
-		}
-
+	return options.wrap(func(writer http.ResponseWriter, request *http.Request) {
 		vars := mux.Vars(request)
 
 		// Add query params as arguments by joining them together per key (query
@@ -53,14 +50,19 @@ func CommandHandlerWithQueryParams(commandFunc interface{}, args command.Args, r
 				vars[k] = strings.Join(request.URL.Query()[k][:], ";")
 			}
 		}
+		RecordArgs(request.Context(), vars)
 
-		resultVals, err := cmdFunc(request.Context(), vars)
+		ctx, cancel := options.withDeadline(request.Context(), request)
+		defer cancel()
+
+		resultVals, err := cmdFunc(ctx, vars)
+		err = translateDeadlineErr(ctx, err)
 
 		if resultsWriter != nil {
 			err = resultsWriter.WriteResults(args, vars, resultVals, err, writer, request)
 		}
-		handleErr(err, writer, request, errHandlers)
-	}
+		handleErr(err, writer, request, options.ErrHandlers)
+	})
 }
 
 type RequestBodyArgConverter interface {
@@ -84,36 +86,45 @@ func RequestBodyAsArg(name string) RequestBodyArgConverterFunc {
 	}
 }
 
-func CommandHandlerRequestBodyArg(bodyConverter RequestBodyArgConverter, commandFunc interface{}, args command.Args, resultsWriter ResultsWriter, errHandlers ...httperr.Handler) http.HandlerFunc {
+func CommandHandlerRequestBodyArg(bodyConverter RequestBodyArgConverter, commandFunc interface{}, args command.Args, resultsWriter ResultsWriter, opts ...HandlerOptions) http.HandlerFunc {
 	cmdFunc := command.MustGetStringMapArgsResultValuesFunc(commandFunc, args)
+	options := firstOptionsOrDefault(opts)
 
-	return func(writer http.ResponseWriter, request *http.Request) {
-		if CatchPanics {
-			defer func() {
-				handleErr(httperr.AsError(recover()), writer, request, errHandlers)
-			}()
-		}
-
+	return options.wrap(func(writer http.ResponseWriter, request *http.Request) {
 		vars := mux.Vars(request)
 		name, value, err := bodyConverter.RequestBodyToArg(request)
 		if err != nil {
-			handleErr(err, writer, request, errHandlers)
+			handleErr(err, writer, request, options.ErrHandlers)
 			return
 		}
 		if _, exists := vars[name]; exists {
 			err = fmt.Errorf("argument '%s' already set by request URL path", name)
-			handleErr(err, writer, request, errHandlers)
+			handleErr(err, writer, request, options.ErrHandlers)
 			return
 		}
 		vars[name] = value
+		RecordArgs(request.Context(), vars)
+
+		ctx, cancel := options.withDeadline(request.Context(), request)
+		defer cancel()
 
-		resultVals, err := cmdFunc(request.Context(), vars)
+		resultVals, err := cmdFunc(ctx, vars)
+		err = translateDeadlineErr(ctx, err)
 
 		if resultsWriter != nil {
 			err = resultsWriter.WriteResults(args, vars, resultVals, err, writer, request)
 		}
-		handleErr(err, writer, request, errHandlers)
+		handleErr(err, writer, request, options.ErrHandlers)
+	})
+}
+
+// translateDeadlineErr replaces err with a 408 Request Timeout if ctx's
+// deadline, set by HandlerOptions.withDeadline, has elapsed.
+func translateDeadlineErr(ctx context.Context, err error) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return httperr.New(http.StatusRequestTimeout)
 	}
+	return err
 }
 
 func handleErr(err error, writer http.ResponseWriter, request *http.Request, errHandlers []httperr.Handler) {
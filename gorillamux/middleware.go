@@ -0,0 +1,189 @@
+package gorillamux
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/ungerik/go-command"
+	"github.com/ungerik/go-httpx/httperr"
+)
+
+// Middleware wraps an http.HandlerFunc to add cross-cutting behaviour
+// such as auth, rate limiting, tracing or panic recovery, without the
+// caller having to wrap the handler returned by CommandHandler by hand.
+type Middleware func(next http.HandlerFunc) http.HandlerFunc
+
+// DefaultMiddlewares is applied by every handler created with a nil
+// HandlerOptions.Middlewares, so that new handlers pick up panic
+// recovery automatically. Assign to it at program startup to change the
+// default for every handler created afterwards.
+var DefaultMiddlewares []Middleware
+
+// HandlerOptions configures the handlers returned by CommandHandler,
+// CommandHandlerWithQueryParams and CommandHandlerRequestBodyArg.
+type HandlerOptions struct {
+	// CommandName is exposed to middlewares via WithCommandName so they
+	// can identify which command is running, for example in log lines
+	// or trace spans.
+	CommandName string
+	// Middlewares wraps the handler, applied in order so that the first
+	// middleware is the outermost one. A nil slice falls back to
+	// DefaultMiddlewares.
+	Middlewares []Middleware
+	// ErrHandlers are tried in order to handle an error returned from
+	// the command or a middleware. A nil slice falls back to
+	// httperr.Handle.
+	ErrHandlers []httperr.Handler
+	// DefaultTimeout is the deadline given to the command's context if
+	// the request does not override it via RequestTimeoutHeader or
+	// RequestTimeoutQueryParam. Zero means no deadline.
+	DefaultTimeout time.Duration
+}
+
+// HandlerOptionsForCommand returns HandlerOptions with CommandName and
+// DefaultTimeout populated from info, so that a timeout set via
+// command.WithTimeout on the dispatcher reaches the HTTP layer without
+// every CommandHandler call having to look up disp.CommandTimeout by
+// hand. Callers that also need Middlewares or ErrHandlers can set those
+// fields on the returned value before passing it on.
+func HandlerOptionsForCommand(info command.CommandInfo) HandlerOptions {
+	name := info.Command
+	if info.SuperCommand != command.Default {
+		name = info.SuperCommand + " " + info.Command
+	}
+	return HandlerOptions{
+		CommandName:    name,
+		DefaultTimeout: info.Timeout,
+	}
+}
+
+// RequestTimeoutHeader is the HTTP header by which a caller can set a
+// per-request timeout, e.g. "X-Request-Timeout: 5s", overriding
+// HandlerOptions.DefaultTimeout.
+const RequestTimeoutHeader = "X-Request-Timeout"
+
+// RequestTimeoutQueryParam is the query parameter by which a caller can
+// set a per-request timeout, e.g. "?timeout=5s", overriding
+// HandlerOptions.DefaultTimeout. It is only consulted if
+// RequestTimeoutHeader is not set.
+const RequestTimeoutQueryParam = "timeout"
+
+// deadline resolves the timeout for request from RequestTimeoutHeader,
+// RequestTimeoutQueryParam or opts.DefaultTimeout, in that order of
+// precedence, and reports whether any timeout applies.
+func (opts HandlerOptions) deadline(request *http.Request) (time.Time, bool) {
+	timeout := opts.DefaultTimeout
+	if header := request.Header.Get(RequestTimeoutHeader); header != "" {
+		if d, err := time.ParseDuration(header); err == nil {
+			timeout = d
+		}
+	} else if param := request.URL.Query().Get(RequestTimeoutQueryParam); param != "" {
+		if d, err := time.ParseDuration(param); err == nil {
+			timeout = d
+		}
+	}
+	if timeout <= 0 {
+		return time.Time{}, false
+	}
+	return time.Now().Add(timeout), true
+}
+
+// withDeadline derives a context from request's context that is
+// cancelled at the deadline resolved by opts.deadline, if any, carrying
+// a command.DeadlineTimer so that a long running cmdFunc can poll
+// cancellation via command.DeadlineTimerFromContext independently of
+// ctx.Done(). The returned cancel must always be called by the caller.
+func (opts HandlerOptions) withDeadline(ctx context.Context, request *http.Request) (context.Context, context.CancelFunc) {
+	deadline, ok := opts.deadline(request)
+	if !ok {
+		return ctx, func() {}
+	}
+	ctx, cancel := context.WithDeadline(ctx, deadline)
+	dt := command.NewDeadlineTimer()
+	dt.SetDeadline(deadline)
+	return command.WithDeadlineTimer(ctx, dt), cancel
+}
+
+func firstOptionsOrDefault(opts []HandlerOptions) HandlerOptions {
+	if len(opts) == 0 {
+		return HandlerOptions{}
+	}
+	return opts[0]
+}
+
+func (opts HandlerOptions) middlewares() []Middleware {
+	if opts.Middlewares != nil {
+		return opts.Middlewares
+	}
+	return DefaultMiddlewares
+}
+
+// wrap applies opts.middlewares() around handler, outermost first,
+// attaches opts.CommandName to the request context so middlewares can
+// read it via CommandNameFromContext, and installs an args recorder
+// ahead of every middleware so that one calling ArgsFromContext after
+// invoking next sees the argument values handler records via
+// RecordArgs, however they were sourced (path, query or body).
+func (opts HandlerOptions) wrap(handler http.HandlerFunc) http.HandlerFunc {
+	for i := len(opts.middlewares()) - 1; i >= 0; i-- {
+		handler = opts.middlewares()[i](handler)
+	}
+	if opts.CommandName != "" {
+		next := handler
+		handler = func(writer http.ResponseWriter, request *http.Request) {
+			next(writer, request.WithContext(WithCommandName(request.Context(), opts.CommandName)))
+		}
+	}
+	next := handler
+	return func(writer http.ResponseWriter, request *http.Request) {
+		next(writer, request.WithContext(WithArgsRecorder(request.Context())))
+	}
+}
+
+type argsRecorderContextKey struct{}
+
+// WithArgsRecorder returns a context carrying an empty, mutable slot for
+// the command's final argument values. A handler calls RecordArgs on it
+// once path, query and body arguments have all been merged, so that a
+// middleware wrapping the handler can read the complete argument set
+// from ArgsFromContext after calling next, rather than only seeing
+// mux.Vars as it existed before the handler ran.
+func WithArgsRecorder(ctx context.Context) context.Context {
+	return context.WithValue(ctx, argsRecorderContextKey{}, &map[string]string{})
+}
+
+// RecordArgs stores vars in the recorder created by WithArgsRecorder, if
+// ctx carries one, replacing anything recorded earlier on the same
+// request.
+func RecordArgs(ctx context.Context, vars map[string]string) {
+	if rec, ok := ctx.Value(argsRecorderContextKey{}).(*map[string]string); ok {
+		*rec = vars
+	}
+}
+
+// ArgsFromContext returns the args recorded via RecordArgs, or nil if
+// none were recorded, e.g. because ctx was never passed through
+// WithArgsRecorder or the handler has not run yet.
+func ArgsFromContext(ctx context.Context) map[string]string {
+	if rec, ok := ctx.Value(argsRecorderContextKey{}).(*map[string]string); ok {
+		return *rec
+	}
+	return nil
+}
+
+type commandNameContextKey struct{}
+
+// WithCommandName returns a context carrying the given command name, so
+// that middlewares further down the chain can identify which command is
+// running, e.g. for logging or tracing.
+func WithCommandName(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, commandNameContextKey{}, name)
+}
+
+// CommandNameFromContext returns the command name stored by
+// WithCommandName, or "" if none was set.
+func CommandNameFromContext(ctx context.Context) string {
+	name, _ := ctx.Value(commandNameContextKey{}).(string)
+	return name
+}
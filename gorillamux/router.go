@@ -0,0 +1,71 @@
+package gorillamux
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// Route describes an HTTP route mounted via Router together with the
+// super/command names of the command.Dispatcher command that backs it,
+// so that generators like the openapi package can match live routes to
+// commands without re-parsing the mux.Router.
+type Route struct {
+	Methods      []string
+	Path         string
+	SuperCommand string
+	Command      string
+}
+
+// Router wraps a *mux.Router and records which handler was mounted on
+// which method and path via HandleCommand, so that generators can
+// reconstruct the full set of live command routes.
+type Router struct {
+	*mux.Router
+	routes []Route
+}
+
+// NewRouter returns a new Router wrapping a fresh *mux.Router.
+func NewRouter() *Router {
+	return &Router{Router: mux.NewRouter()}
+}
+
+// documentedMethods lists the HTTP methods recorded for a route mounted
+// with no explicit methods, matching every verb openapi.PathItem can
+// describe, so such a route still gets operations in the generated
+// spec instead of silently dropping out of it.
+var documentedMethods = []string{
+	http.MethodGet,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodDelete,
+	http.MethodPatch,
+}
+
+// HandleCommand registers handler for path and methods, and records the
+// route together with the superCommand and command names it serves.
+// superCommand is command.Default for routes that are not part of a
+// super command. The underlying mux.Route is left accepting any method
+// when methods is empty, but the recorded Route.Methods defaults to
+// documentedMethods so generators such as openapi still produce an
+// operation for the route instead of one with every verb nil.
+func (router *Router) HandleCommand(path, superCommand, command string, handler http.HandlerFunc, methods ...string) *mux.Route {
+	route := router.Router.Handle(path, handler)
+	if len(methods) > 0 {
+		route = route.Methods(methods...)
+	} else {
+		methods = documentedMethods
+	}
+	router.routes = append(router.routes, Route{
+		Methods:      methods,
+		Path:         path,
+		SuperCommand: superCommand,
+		Command:      command,
+	})
+	return route
+}
+
+// Routes returns the routes recorded via HandleCommand.
+func (router *Router) Routes() []Route {
+	return router.routes
+}
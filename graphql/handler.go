@@ -0,0 +1,119 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	command "github.com/ungerik/go-command"
+	"github.com/ungerik/go-command/middleware/authmw"
+	"github.com/ungerik/go-httpx/httperr"
+)
+
+// RoleChecker authorizes a field's AuthRole against the incoming
+// request, wired to the @auth directive emitted by SDL.
+type RoleChecker interface {
+	HasRole(request *http.Request, role string) bool
+}
+
+// RoleCheckerFromVerifier adapts an authmw.RoleVerifier to RoleChecker,
+// so Schema.Handler's @auth(role: ...) checks are backed by the same
+// Verifier wired into authmw.Middleware for request authentication,
+// instead of a second, disconnected authorization path.
+func RoleCheckerFromVerifier(verifier authmw.RoleVerifier) RoleChecker {
+	return roleCheckerFunc(verifier.VerifyRole)
+}
+
+type roleCheckerFunc func(request *http.Request, role string) bool
+
+func (f roleCheckerFunc) HasRole(request *http.Request, role string) bool {
+	return f(request, role)
+}
+
+type requestBody struct {
+	Query         string `json:"query"`
+	OperationName string `json:"operationName"`
+}
+
+type responseBody struct {
+	Data   map[string]interface{} `json:"data,omitempty"`
+	Errors []responseError        `json:"errors,omitempty"`
+}
+
+type responseError struct {
+	Message string `json:"message"`
+	Path    string `json:"path,omitempty"`
+}
+
+// Handler returns an http.HandlerFunc that executes GraphQL queries
+// against the schema's commands, authorizing fields that have an
+// AuthRole via checker if it is not nil.
+func (s *Schema) Handler(checker RoleChecker) http.HandlerFunc {
+	queryFields := make(map[string]Field, len(s.Query))
+	for _, field := range s.Query {
+		queryFields[field.Name] = field
+	}
+	mutationFields := make(map[string]Field, len(s.Mutation))
+	for _, field := range s.Mutation {
+		mutationFields[field.Name] = field
+	}
+
+	return func(writer http.ResponseWriter, request *http.Request) {
+		var body requestBody
+		if err := json.NewDecoder(request.Body).Decode(&body); err != nil {
+			httperr.BadRequest.ServeHTTP(writer, request)
+			return
+		}
+
+		opType, selections, err := parseSelections(body.Query)
+		if err != nil {
+			writeResponse(writer, responseBody{Errors: []responseError{{Message: err.Error()}}})
+			return
+		}
+
+		// Only opType's own fields are in scope: a mutation field must
+		// never be reachable by wrapping it in "query { ... }", since
+		// query operations are expected to be side-effect free and may
+		// be replayed or prefetched by clients.
+		fields := queryFields
+		if opType == "mutation" {
+			fields = mutationFields
+		}
+
+		data := make(map[string]interface{}, len(selections))
+		var errs []responseError
+		for _, sel := range selections {
+			field, ok := fields[sel.name]
+			if !ok {
+				errs = append(errs, responseError{Message: fmt.Sprintf("unknown %s field %q", opType, sel.name), Path: sel.name})
+				continue
+			}
+			if field.AuthRole != "" && checker != nil && !checker.HasRole(request, field.AuthRole) {
+				errs = append(errs, responseError{Message: fmt.Sprintf("not authorized for %q", sel.name), Path: sel.name})
+				continue
+			}
+			result, err := resolve(request.Context(), field, sel.args)
+			if err != nil {
+				errs = append(errs, responseError{Message: err.Error(), Path: sel.name})
+				continue
+			}
+			data[sel.name] = result
+		}
+
+		writeResponse(writer, responseBody{Data: data, Errors: errs})
+	}
+}
+
+// resolve invokes field's command via the same
+// MustGetStringMapArgsResultValuesFunc path used by the gorillamux
+// handlers, so the GraphQL and REST surfaces share one execution path.
+func resolve(ctx context.Context, field Field, args map[string]string) (interface{}, error) {
+	cmdFunc := command.MustGetStringMapArgsResultValuesFunc(field.info.CommandFunc, field.info.Args)
+	return cmdFunc(ctx, args)
+}
+
+func writeResponse(writer http.ResponseWriter, body responseBody) {
+	writer.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(writer).Encode(body)
+}
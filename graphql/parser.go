@@ -0,0 +1,161 @@
+package graphql
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type selection struct {
+	name string
+	args map[string]string
+}
+
+// parseSelections parses a minimal subset of GraphQL query documents: an
+// optional "query"/"mutation" keyword and operation name, followed by a
+// flat selection set of fields with scalar arguments, e.g.:
+//
+//	mutation { createUser(name: "ada", age: 31) }
+//
+// It returns the operation type ("query" or "mutation"), defaulting to
+// "query" for the shorthand form with no keyword, so that Handler can
+// reject mutation fields selected under a query operation.
+//
+// Nested selection sets and fragments are not supported, since a field
+// resolves directly to a command's result value rather than a further
+// selectable object.
+func parseSelections(query string) (opType string, selections []selection, err error) {
+	p := &parser{input: query}
+	opType = "query"
+	if p.skipKeyword("mutation") {
+		opType = "mutation"
+	} else {
+		p.skipKeyword("query")
+	}
+	p.readName() // optional operation name
+	if !p.consume('{') {
+		return "", nil, fmt.Errorf("expected '{' at %q", p.rest())
+	}
+
+	for {
+		p.skipSpace()
+		if p.consume('}') {
+			return opType, selections, nil
+		}
+		name := p.readName()
+		if name == "" {
+			return "", nil, fmt.Errorf("expected field name at %q", p.rest())
+		}
+		args, err := p.readArguments()
+		if err != nil {
+			return "", nil, err
+		}
+		selections = append(selections, selection{name: name, args: args})
+	}
+}
+
+type parser struct {
+	input string
+	pos   int
+}
+
+func (p *parser) rest() string { return p.input[p.pos:] }
+
+func (p *parser) skipSpace() {
+	for p.pos < len(p.input) && unicode.IsSpace(rune(p.input[p.pos])) {
+		p.pos++
+	}
+}
+
+// skipKeyword consumes keyword if the input is at a word boundary after
+// it, e.g. "query" must not match the start of a field named "queryFoo".
+func (p *parser) skipKeyword(keyword string) bool {
+	p.skipSpace()
+	rest := p.rest()
+	if !strings.HasPrefix(rest, keyword) {
+		return false
+	}
+	if len(rest) > len(keyword) {
+		next := rune(rest[len(keyword)])
+		if unicode.IsLetter(next) || unicode.IsDigit(next) || next == '_' {
+			return false
+		}
+	}
+	p.pos += len(keyword)
+	return true
+}
+
+func (p *parser) consume(c byte) bool {
+	p.skipSpace()
+	if p.pos < len(p.input) && p.input[p.pos] == c {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) readName() string {
+	p.skipSpace()
+	start := p.pos
+	for p.pos < len(p.input) && (unicode.IsLetter(rune(p.input[p.pos])) || unicode.IsDigit(rune(p.input[p.pos])) || p.input[p.pos] == '_') {
+		p.pos++
+	}
+	return p.input[start:p.pos]
+}
+
+func (p *parser) readArguments() (map[string]string, error) {
+	p.skipSpace()
+	if !p.consume('(') {
+		return nil, nil
+	}
+	args := make(map[string]string)
+	for {
+		p.skipSpace()
+		if p.consume(')') {
+			return args, nil
+		}
+		name := p.readName()
+		if name == "" {
+			return nil, fmt.Errorf("expected argument name at %q", p.rest())
+		}
+		if !p.consume(':') {
+			return nil, fmt.Errorf("expected ':' after argument %q", name)
+		}
+		value, err := p.readValue()
+		if err != nil {
+			return nil, err
+		}
+		args[name] = value
+		p.skipSpace()
+		p.consume(',')
+	}
+}
+
+func (p *parser) readValue() (string, error) {
+	p.skipSpace()
+	if p.pos >= len(p.input) {
+		return "", fmt.Errorf("unexpected end of query")
+	}
+	if p.input[p.pos] == '"' {
+		return p.readString()
+	}
+	start := p.pos
+	for p.pos < len(p.input) && p.input[p.pos] != ',' && p.input[p.pos] != ')' && !unicode.IsSpace(rune(p.input[p.pos])) {
+		p.pos++
+	}
+	return p.input[start:p.pos], nil
+}
+
+func (p *parser) readString() (string, error) {
+	p.pos++ // opening quote
+	start := p.pos
+	for p.pos < len(p.input) && p.input[p.pos] != '"' {
+		p.pos++
+	}
+	if p.pos >= len(p.input) {
+		return "", fmt.Errorf("unterminated string")
+	}
+	value := p.input[start:p.pos]
+	p.pos++ // closing quote
+	return value, nil
+}
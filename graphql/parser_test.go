@@ -0,0 +1,63 @@
+package graphql
+
+import "testing"
+
+func TestParseSelectionsShorthandDefaultsToQuery(t *testing.T) {
+	opType, selections, err := parseSelections(`{ ping }`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opType != "query" {
+		t.Errorf("opType = %q, want %q", opType, "query")
+	}
+	if len(selections) != 1 || selections[0].name != "ping" {
+		t.Errorf("selections = %+v", selections)
+	}
+}
+
+func TestParseSelectionsMutationKeyword(t *testing.T) {
+	opType, selections, err := parseSelections(`mutation { createUser(name: "ada", age: 31) }`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opType != "mutation" {
+		t.Errorf("opType = %q, want %q", opType, "mutation")
+	}
+	if len(selections) != 1 || selections[0].name != "createUser" {
+		t.Fatalf("selections = %+v", selections)
+	}
+	if selections[0].args["name"] != "ada" || selections[0].args["age"] != "31" {
+		t.Errorf("args = %+v", selections[0].args)
+	}
+}
+
+func TestParseSelectionsNamedQueryOperation(t *testing.T) {
+	opType, _, err := parseSelections(`query GetUser { user(id: "1") }`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opType != "query" {
+		t.Errorf("opType = %q, want %q", opType, "query")
+	}
+}
+
+// TestParseSelectionsKeywordPrefixField guards against skipKeyword
+// matching "query"/"mutation" as a prefix of an unrelated field name.
+func TestParseSelectionsKeywordPrefixField(t *testing.T) {
+	opType, selections, err := parseSelections(`{ queryStatus }`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opType != "query" {
+		t.Errorf("opType = %q, want %q", opType, "query")
+	}
+	if len(selections) != 1 || selections[0].name != "queryStatus" {
+		t.Fatalf("selections = %+v, want a single queryStatus field", selections)
+	}
+}
+
+func TestParseSelectionsMissingBrace(t *testing.T) {
+	if _, _, err := parseSelections(`mutation createUser(name: "ada")`); err == nil {
+		t.Fatal("expected an error for a query missing '{'")
+	}
+}
@@ -0,0 +1,62 @@
+package graphql
+
+import (
+	"html/template"
+	"net/http"
+)
+
+// PlaygroundTemplate renders a minimal GraphiQL page pointed at
+// EndpointURL, served next to htmlform.SwaggerUITemplate for schemas
+// that expose a GraphQL endpoint via Schema.Handler.
+var PlaygroundTemplate = `
+<!DOCTYPE html>
+<html lang="en">
+<head>
+	<meta charset="utf-8"/>
+	<title>{{.Title}}</title>
+	<link rel="stylesheet" href="https://unpkg.com/graphiql/graphiql.min.css"/>
+</head>
+<body style="margin:0">
+<div id="graphiql" style="height:100vh"></div>
+<script src="https://unpkg.com/react/umd/react.production.min.js"></script>
+<script src="https://unpkg.com/react-dom/umd/react-dom.production.min.js"></script>
+<script src="https://unpkg.com/graphiql/graphiql.min.js"></script>
+<script>
+	function graphQLFetcher(params) {
+		return fetch("{{.EndpointURL}}", {
+			method: "post",
+			headers: {"Content-Type": "application/json"},
+			body: JSON.stringify(params),
+		}).then(function(response) { return response.json(); });
+	}
+	ReactDOM.render(
+		React.createElement(GraphiQL, {fetcher: graphQLFetcher}),
+		document.getElementById("graphiql"),
+	);
+</script>
+</body>
+</html>
+`
+
+// PlaygroundData is the template data for PlaygroundTemplate.
+type PlaygroundData struct {
+	Title       string
+	EndpointURL string
+}
+
+// PlaygroundHandler returns an http.HandlerFunc serving a GraphiQL page
+// wired to the GraphQL endpoint at endpointURL. Schema.Handler does not
+// resolve the __schema/__type introspection fields GraphiQL's docs
+// explorer and autocomplete depend on, so those panels stay empty;
+// check the Schema.SDL() output into the repo as schema.graphql for
+// human-readable docs instead.
+func PlaygroundHandler(title, endpointURL string) (http.HandlerFunc, error) {
+	t, err := template.New("graphql-playground").Parse(PlaygroundTemplate)
+	if err != nil {
+		return nil, err
+	}
+	return func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set("Content-Type", "text/html; charset=utf-8")
+		t.Execute(writer, PlaygroundData{Title: title, EndpointURL: endpointURL})
+	}, nil
+}
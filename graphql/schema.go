@@ -0,0 +1,157 @@
+// Package graphql mirrors a command.SuperStringArgsDispatcher as a
+// GraphQL schema: read-only commands (command.WithReadOnly) become
+// Query fields, everything else becomes a Mutation field. Argument
+// types are inferred from command.Arg.Type, and result types are
+// reflected from the command's Go function signature.
+package graphql
+
+import (
+	"reflect"
+	"sort"
+
+	command "github.com/ungerik/go-command"
+)
+
+// Type is a minimal GraphQL type reference, only covering the scalar
+// and list types that command.Arg.Type and reflected result types need.
+type Type struct {
+	Name    string
+	NonNull bool
+	ListOf  *Type
+}
+
+func (t Type) String() string {
+	var s string
+	if t.ListOf != nil {
+		s = "[" + t.ListOf.String() + "]"
+	} else {
+		s = t.Name
+	}
+	if t.NonNull {
+		s += "!"
+	}
+	return s
+}
+
+// Argument is a single input argument of a Field.
+type Argument struct {
+	Name string
+	Type Type
+}
+
+// Field describes a single command exposed as a GraphQL field.
+type Field struct {
+	Name       string
+	Arguments  []Argument
+	Type       Type
+	Deprecated string // reason, empty if the command is not deprecated
+	AuthRole   string // empty if the command requires no auth role
+
+	info command.CommandInfo
+}
+
+// Schema is the GraphQL schema generated for a
+// command.SuperStringArgsDispatcher.
+type Schema struct {
+	Query    []Field
+	Mutation []Field
+}
+
+// New walks disp and builds a Schema from its commands. Query and
+// Mutation are sorted by field name so that the generated schema is
+// stable across runs, matching admin.List since WalkCommands otherwise
+// iterates in Go's randomized map order.
+func New(disp *command.SuperStringArgsDispatcher) *Schema {
+	schema := &Schema{}
+	disp.WalkCommands(func(cmd command.CommandInfo) {
+		field := newField(cmd)
+		if cmd.ReadOnly {
+			schema.Query = append(schema.Query, field)
+		} else {
+			schema.Mutation = append(schema.Mutation, field)
+		}
+	})
+	sortFields(schema.Query)
+	sortFields(schema.Mutation)
+	return schema
+}
+
+func sortFields(fields []Field) {
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i].Name < fields[j].Name
+	})
+}
+
+func newField(cmd command.CommandInfo) Field {
+	field := Field{
+		Name:       fieldName(cmd),
+		Type:       resultType(cmd.CommandFunc),
+		Deprecated: cmd.Deprecated,
+		AuthRole:   cmd.AuthRole,
+		info:       cmd,
+	}
+	for _, arg := range cmd.Args.Args() {
+		field.Arguments = append(field.Arguments, Argument{Name: arg.Name, Type: argType(arg.Type)})
+	}
+	return field
+}
+
+func fieldName(cmd command.CommandInfo) string {
+	if cmd.SuperCommand == command.Default {
+		return cmd.Command
+	}
+	return cmd.SuperCommand + "_" + cmd.Command
+}
+
+func argType(typeName string) Type {
+	switch typeName {
+	case "int":
+		return Type{Name: "Int", NonNull: true}
+	case "bool":
+		return Type{Name: "Boolean", NonNull: true}
+	case "float", "double":
+		return Type{Name: "Float", NonNull: true}
+	default:
+		return Type{Name: "String", NonNull: true}
+	}
+}
+
+func resultType(commandFunc interface{}) Type {
+	fnType := reflect.TypeOf(commandFunc)
+	if fnType == nil || fnType.Kind() != reflect.Func {
+		return Type{Name: "Boolean"}
+	}
+	errType := reflect.TypeOf((*error)(nil)).Elem()
+	for i := 0; i < fnType.NumOut(); i++ {
+		out := fnType.Out(i)
+		if out == errType {
+			continue
+		}
+		return typeFromReflect(out)
+	}
+	return Type{Name: "Boolean"}
+}
+
+func typeFromReflect(t reflect.Type) Type {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return typeFromReflect(t.Elem())
+	case reflect.Slice, reflect.Array:
+		elem := typeFromReflect(t.Elem())
+		return Type{ListOf: &elem}
+	case reflect.Struct, reflect.Map:
+		if t.Name() == "" {
+			return Type{Name: "JSON"}
+		}
+		return Type{Name: t.Name()}
+	case reflect.Bool:
+		return Type{Name: "Boolean"}
+	case reflect.Float32, reflect.Float64:
+		return Type{Name: "Float"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return Type{Name: "Int"}
+	default:
+		return Type{Name: "String"}
+	}
+}
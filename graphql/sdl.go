@@ -0,0 +1,50 @@
+package graphql
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SDL renders the schema as GraphQL Schema Definition Language, so that
+// it can be checked into a repo as schema.graphql.
+func (s *Schema) SDL() string {
+	var b strings.Builder
+	writeObjectType(&b, "Query", s.Query)
+	writeObjectType(&b, "Mutation", s.Mutation)
+	return b.String()
+}
+
+func writeObjectType(b *strings.Builder, name string, fields []Field) {
+	if len(fields) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "type %s {\n", name)
+	for _, field := range fields {
+		fmt.Fprintf(b, "  %s%s: %s%s\n", field.Name, argsSDL(field.Arguments), field.Type, directivesSDL(field))
+	}
+	b.WriteString("}\n\n")
+}
+
+// argsSDL returns the parenthesized argument list, or "" for a field
+// without arguments, since GraphQL does not allow an empty "()".
+func argsSDL(args []Argument) string {
+	if len(args) == 0 {
+		return ""
+	}
+	parts := make([]string, len(args))
+	for i, arg := range args {
+		parts[i] = fmt.Sprintf("%s: %s", arg.Name, arg.Type)
+	}
+	return "(" + strings.Join(parts, ", ") + ")"
+}
+
+func directivesSDL(field Field) string {
+	var b strings.Builder
+	if field.Deprecated != "" {
+		fmt.Fprintf(&b, " @deprecated(reason: %q)", field.Deprecated)
+	}
+	if field.AuthRole != "" {
+		fmt.Fprintf(&b, " @auth(role: %q)", field.AuthRole)
+	}
+	return b.String()
+}
@@ -0,0 +1,78 @@
+package htmlform
+
+import command "github.com/ungerik/go-command"
+
+// Option is a selectable value for a Field of type "select".
+type Option struct {
+	Value interface{}
+	Label string
+}
+
+// Field describes a single form field rendered by FormTemplate.
+type Field struct {
+	Name    string
+	Label   string
+	Type    string
+	Value   string
+	Options []Option
+}
+
+// Data is the template data consumed by FormTemplate.
+type Data struct {
+	Title            string
+	Fields           []Field
+	SubmitButtonText string
+}
+
+// FieldsFromArgs maps the command.Arg values of args to Fields, using
+// values as the pre-filled Field.Value keyed by Arg.Name. The mapping
+// from Arg.Type to the HTML input type is:
+//
+//	"int", "float" -> "number"
+//	"bool"         -> "checkbox"
+//	"time"         -> "datetime-local"
+//	"[]byte", "file" -> "file"
+//	anything else  -> "text", or "select" if the Arg has Options
+func FieldsFromArgs(args command.Args, values map[string]string) []Field {
+	argList := args.Args()
+	fields := make([]Field, len(argList))
+	for i, arg := range argList {
+		fields[i] = Field{
+			Name:    arg.Name,
+			Label:   arg.Name,
+			Type:    fieldType(arg),
+			Value:   values[arg.Name],
+			Options: optionsFromArg(arg),
+		}
+	}
+	return fields
+}
+
+func fieldType(arg command.Arg) string {
+	if len(arg.Options) > 0 {
+		return "select"
+	}
+	switch arg.Type {
+	case "int", "float":
+		return "number"
+	case "bool":
+		return "checkbox"
+	case "time":
+		return "datetime-local"
+	case "[]byte", "file":
+		return "file"
+	default:
+		return "text"
+	}
+}
+
+func optionsFromArg(arg command.Arg) []Option {
+	if len(arg.Options) == 0 {
+		return nil
+	}
+	options := make([]Option, len(arg.Options))
+	for i, value := range arg.Options {
+		options[i] = Option{Value: value, Label: value}
+	}
+	return options
+}
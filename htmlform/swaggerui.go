@@ -0,0 +1,51 @@
+package htmlform
+
+import (
+	"html/template"
+	"net/http"
+)
+
+// SwaggerUITemplate renders a Swagger-UI page that loads its spec from
+// SpecURL, served next to FormTemplate for commands that expose an
+// OpenAPI document via the openapi package.
+var SwaggerUITemplate = `
+<!DOCTYPE html>
+<html lang="en">
+<head>
+	<meta charset="utf-8"/>
+	<title>{{.Title}}</title>
+	<link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist/swagger-ui.css"/>
+</head>
+<body>
+<div id="swagger-ui"></div>
+<script src="https://unpkg.com/swagger-ui-dist/swagger-ui-bundle.js"></script>
+<script>
+	window.onload = function() {
+		SwaggerUIBundle({
+			url: "{{.SpecURL}}",
+			dom_id: "#swagger-ui",
+		});
+	};
+</script>
+</body>
+</html>
+`
+
+// SwaggerUIData is the template data for SwaggerUITemplate.
+type SwaggerUIData struct {
+	Title   string
+	SpecURL string
+}
+
+// SwaggerUIHandler returns an http.HandlerFunc serving a Swagger-UI page
+// for the OpenAPI document available at specURL.
+func SwaggerUIHandler(title, specURL string) (http.HandlerFunc, error) {
+	t, err := template.New("swaggerui").Parse(SwaggerUITemplate)
+	if err != nil {
+		return nil, err
+	}
+	return func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set("Content-Type", "text/html; charset=utf-8")
+		t.Execute(writer, SwaggerUIData{Title: title, SpecURL: specURL})
+	}, nil
+}
@@ -0,0 +1,98 @@
+// Package ratelimitmw provides a token-bucket rate limiting
+// gorillamux.Middleware, keyed by a mux variable or by the client's
+// remote address.
+package ratelimitmw
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/ungerik/go-httpx/httperr"
+)
+
+// KeyFunc extracts the rate limit key from a request.
+type KeyFunc func(request *http.Request) string
+
+// KeyByRemoteAddr keys by the client's remote address.
+func KeyByRemoteAddr(request *http.Request) string {
+	return request.RemoteAddr
+}
+
+// KeyByMuxVar returns a KeyFunc that keys by the named mux variable,
+// falling back to the client's remote address if it is not set.
+func KeyByMuxVar(name string) KeyFunc {
+	return func(request *http.Request) string {
+		if value := mux.Vars(request)[name]; value != "" {
+			return value
+		}
+		return request.RemoteAddr
+	}
+}
+
+type bucket struct {
+	tokens     float64
+	lastRefill time.Time
+}
+
+// Limiter is a token bucket rate limiter keyed by KeyFunc.
+type Limiter struct {
+	rate    float64 // tokens added per second
+	burst   float64 // maximum tokens, and tokens available at start
+	keyFunc KeyFunc
+
+	mtx     sync.Mutex
+	buckets map[string]*bucket
+}
+
+// NewLimiter returns a Limiter that allows up to burst requests
+// immediately per key and then refills at rate tokens per second. A nil
+// keyFunc keys by KeyByRemoteAddr.
+func NewLimiter(rate, burst float64, keyFunc KeyFunc) *Limiter {
+	if keyFunc == nil {
+		keyFunc = KeyByRemoteAddr
+	}
+	return &Limiter{
+		rate:    rate,
+		burst:   burst,
+		keyFunc: keyFunc,
+		buckets: make(map[string]*bucket),
+	}
+}
+
+func (l *Limiter) allow(key string) bool {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	now := time.Now()
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: l.burst, lastRefill: now}
+		l.buckets[key] = b
+	}
+
+	b.tokens += now.Sub(b.lastRefill).Seconds() * l.rate
+	if b.tokens > l.burst {
+		b.tokens = l.burst
+	}
+	b.lastRefill = now
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// Middleware returns a gorillamux.Middleware that rejects requests over
+// the limit with a 429 Too Many Requests.
+func (l *Limiter) Middleware(next http.HandlerFunc) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		if !l.allow(l.keyFunc(request)) {
+			httperr.Handle(httperr.New(http.StatusTooManyRequests), writer, request)
+			return
+		}
+		next(writer, request)
+	}
+}
@@ -0,0 +1,42 @@
+package ratelimitmw
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLimiterAllowsBurstThenRejects(t *testing.T) {
+	l := NewLimiter(1, 3, nil)
+
+	for i := 0; i < 3; i++ {
+		if !l.allow("client") {
+			t.Fatalf("request %d within burst was rejected", i)
+		}
+	}
+	if l.allow("client") {
+		t.Fatal("request beyond burst was allowed")
+	}
+}
+
+func TestLimiterTracksKeysIndependently(t *testing.T) {
+	l := NewLimiter(1, 1, nil)
+
+	if !l.allow("a") {
+		t.Fatal("first request for key a was rejected")
+	}
+	if !l.allow("b") {
+		t.Fatal("first request for key b was rejected, but it has its own bucket")
+	}
+	if l.allow("a") {
+		t.Fatal("second immediate request for key a was allowed")
+	}
+}
+
+func TestKeyByMuxVarFallsBackToRemoteAddr(t *testing.T) {
+	keyFunc := KeyByMuxVar("id")
+	req := httptest.NewRequest("GET", "/", nil)
+	req.RemoteAddr = "203.0.113.1:1234"
+	if got := keyFunc(req); got != "203.0.113.1:1234" {
+		t.Errorf("keyFunc(req) = %q, want the remote address fallback", got)
+	}
+}
@@ -0,0 +1,24 @@
+// Package recovermw provides a gorillamux.Middleware that recovers from
+// panics raised by the wrapped handler and reports them via httperr,
+// replacing the ad-hoc CatchPanics defer previously built into the
+// gorillamux handlers.
+package recovermw
+
+import (
+	"net/http"
+
+	"github.com/ungerik/go-httpx/httperr"
+)
+
+// Middleware recovers from panics raised by next and reports them via
+// httperr.Handle.
+func Middleware(next http.HandlerFunc) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		defer func() {
+			if r := recover(); r != nil {
+				httperr.Handle(httperr.AsError(r), writer, request)
+			}
+		}()
+		next(writer, request)
+	}
+}
@@ -0,0 +1,57 @@
+// Package tracingmw provides an OpenTelemetry tracing gorillamux.Middleware
+// that starts a span per request, named after the command set via
+// gorillamux.WithCommandName. Argument values are redacted by default;
+// pass the argument names to allow through AllowValues.
+package tracingmw
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/ungerik/go-command/gorillamux"
+)
+
+// Middleware returns a gorillamux.Middleware that starts a span for
+// every request, tagged with the command name and the names of its
+// arguments. Only arguments named in allowValues have their value
+// attached to the span; every other recorded argument is only tagged
+// as present, with its value left out, so that secrets are not leaked
+// into traces by default.
+//
+// Tagging happens after next runs, reading gorillamux.ArgsFromContext,
+// since a command's arguments may come from the request body or query
+// string and are not known from mux.Vars alone until the handler has
+// merged them.
+func Middleware(tracerName string, allowValues ...string) func(next http.HandlerFunc) http.HandlerFunc {
+	tracer := otel.Tracer(tracerName)
+	allowed := make(map[string]bool, len(allowValues))
+	for _, name := range allowValues {
+		allowed[name] = true
+	}
+
+	return func(next http.HandlerFunc) http.HandlerFunc {
+		return func(writer http.ResponseWriter, request *http.Request) {
+			ctx, span := tracer.Start(request.Context(), spanName(request))
+			defer span.End()
+
+			next(writer, request.WithContext(ctx))
+
+			for name, value := range gorillamux.ArgsFromContext(ctx) {
+				if allowed[name] {
+					span.SetAttributes(attribute.String("command.arg."+name, value))
+				} else {
+					span.SetAttributes(attribute.Bool("command.arg."+name, true))
+				}
+			}
+		}
+	}
+}
+
+func spanName(request *http.Request) string {
+	if name := gorillamux.CommandNameFromContext(request.Context()); name != "" {
+		return name
+	}
+	return request.URL.Path
+}
@@ -0,0 +1,210 @@
+// Package openapi generates an OpenAPI 3.0 document describing the
+// commands registered on a command.SuperStringArgsDispatcher, matching
+// every command against the live routes recorded by a gorillamux.Router
+// so that the generated document reflects what is actually served.
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+
+	command "github.com/ungerik/go-command"
+	"github.com/ungerik/go-command/gorillamux"
+)
+
+// Info mirrors the OpenAPI "info" object.
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// Document is a minimal OpenAPI 3.0 document, only modelling the parts
+// needed to describe command.Dispatcher commands as operations.
+type Document struct {
+	OpenAPI string               `json:"openapi"`
+	Info    Info                 `json:"info"`
+	Paths   map[string]*PathItem `json:"paths"`
+}
+
+// PathItem holds the operations mounted on a single path.
+type PathItem struct {
+	Get    *Operation `json:"get,omitempty"`
+	Post   *Operation `json:"post,omitempty"`
+	Put    *Operation `json:"put,omitempty"`
+	Delete *Operation `json:"delete,omitempty"`
+	Patch  *Operation `json:"patch,omitempty"`
+}
+
+// Operation describes a single command as an OpenAPI operation.
+type Operation struct {
+	Tags        []string            `json:"tags,omitempty"`
+	Summary     string              `json:"summary,omitempty"`
+	OperationID string              `json:"operationId"`
+	Parameters  []Parameter         `json:"parameters,omitempty"`
+	Responses   map[string]Response `json:"responses"`
+}
+
+// Parameter describes a command.Arg as an OpenAPI parameter.
+type Parameter struct {
+	Name        string `json:"name"`
+	In          string `json:"in"`
+	Description string `json:"description,omitempty"`
+	Required    bool   `json:"required"`
+	Schema      Schema `json:"schema"`
+}
+
+// Schema is a minimal JSON schema, only covering the primitive types
+// and arrays that command.Arg.Type and reflected result types need.
+type Schema struct {
+	Type  string  `json:"type"`
+	Items *Schema `json:"items,omitempty"`
+}
+
+// Response describes the result of a command.
+type Response struct {
+	Description string           `json:"description"`
+	Content     map[string]Media `json:"content,omitempty"`
+}
+
+// Media holds the schema for a response content type.
+type Media struct {
+	Schema Schema `json:"schema"`
+}
+
+// New generates a Document for the commands in disp, placing each
+// command's operation at the path it is actually mounted on according to
+// routes. Commands without a matching route are omitted, since an
+// OpenAPI operation always needs a path.
+func New(info Info, disp *command.SuperStringArgsDispatcher, routes []gorillamux.Route) *Document {
+	doc := &Document{
+		OpenAPI: "3.0.3",
+		Info:    info,
+		Paths:   make(map[string]*PathItem),
+	}
+
+	type key struct{ superCommand, command string }
+	routeForCommand := make(map[key]gorillamux.Route, len(routes))
+	for _, route := range routes {
+		routeForCommand[key{route.SuperCommand, route.Command}] = route
+	}
+
+	disp.WalkCommands(func(cmd command.CommandInfo) {
+		route, ok := routeForCommand[key{cmd.SuperCommand, cmd.Command}]
+		if !ok {
+			return
+		}
+		op := newOperation(cmd)
+		item := doc.Paths[route.Path]
+		if item == nil {
+			item = new(PathItem)
+			doc.Paths[route.Path] = item
+		}
+		for _, method := range route.Methods {
+			item.setOperation(method, op)
+		}
+	})
+
+	return doc
+}
+
+// Handler returns an http.HandlerFunc that serves doc as JSON, suitable
+// for mounting at /openapi.json.
+func (doc *Document) Handler() http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode(doc)
+	}
+}
+
+func (item *PathItem) setOperation(method string, op *Operation) {
+	switch method {
+	case http.MethodGet:
+		item.Get = op
+	case http.MethodPost:
+		item.Post = op
+	case http.MethodPut:
+		item.Put = op
+	case http.MethodDelete:
+		item.Delete = op
+	case http.MethodPatch:
+		item.Patch = op
+	}
+}
+
+func newOperation(cmd command.CommandInfo) *Operation {
+	op := &Operation{
+		OperationID: cmd.SuperCommand + "." + cmd.Command,
+		Summary:     cmd.Description,
+		Responses:   map[string]Response{"200": newResponse(cmd.CommandFunc)},
+	}
+	if cmd.SuperCommand != command.Default {
+		op.Tags = []string{cmd.SuperCommand}
+	}
+	for _, arg := range cmd.Args.Args() {
+		op.Parameters = append(op.Parameters, Parameter{
+			Name:        arg.Name,
+			In:          "query",
+			Description: arg.Description,
+			Required:    true,
+			Schema:      Schema{Type: schemaType(arg.Type)},
+		})
+	}
+	return op
+}
+
+func schemaType(argType string) string {
+	switch argType {
+	case "int":
+		return "integer"
+	case "bool":
+		return "boolean"
+	case "float", "double":
+		return "number"
+	default:
+		return "string"
+	}
+}
+
+func newResponse(commandFunc interface{}) Response {
+	fnType := reflect.TypeOf(commandFunc)
+	if fnType == nil || fnType.Kind() != reflect.Func {
+		return Response{Description: "OK"}
+	}
+	errType := reflect.TypeOf((*error)(nil)).Elem()
+	for i := 0; i < fnType.NumOut(); i++ {
+		out := fnType.Out(i)
+		if out == errType {
+			continue
+		}
+		return Response{
+			Description: "OK",
+			Content: map[string]Media{
+				"application/json": {Schema: schemaFromType(out)},
+			},
+		}
+	}
+	return Response{Description: "OK"}
+}
+
+func schemaFromType(t reflect.Type) Schema {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return schemaFromType(t.Elem())
+	case reflect.Slice, reflect.Array:
+		elem := schemaFromType(t.Elem())
+		return Schema{Type: "array", Items: &elem}
+	case reflect.Struct, reflect.Map:
+		return Schema{Type: "object"}
+	case reflect.Bool:
+		return Schema{Type: "boolean"}
+	case reflect.Float32, reflect.Float64:
+		return Schema{Type: "number"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return Schema{Type: "integer"}
+	default:
+		return Schema{Type: "string"}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"io"
 	"sort"
 	"strings"
+	"time"
 	"unicode"
 )
 
@@ -28,6 +29,115 @@ type stringArgsCommand struct {
 	commandFunc     interface{}
 	stringArgsFunc  StringArgsFunc
 	resultsHandlers []ResultsHandler
+	timeout         time.Duration
+	readOnly        bool
+	deprecated      string
+	authRole        string
+}
+
+func (cmd *stringArgsCommand) info(superCommand string) CommandInfo {
+	return CommandInfo{
+		SuperCommand: superCommand,
+		Command:      cmd.command,
+		Description:  cmd.description,
+		Args:         cmd.args,
+		CommandFunc:  cmd.commandFunc,
+		Timeout:      cmd.timeout,
+		ReadOnly:     cmd.readOnly,
+		Deprecated:   cmd.deprecated,
+		AuthRole:     cmd.authRole,
+	}
+}
+
+// CommandInfo describes a single command as reported by WalkCommands,
+// gathering everything generators such as the openapi, admin and
+// graphql packages need without exposing the dispatchers' internals.
+type CommandInfo struct {
+	SuperCommand string
+	Command      string
+	Description  string
+	Args         Args
+	CommandFunc  interface{}
+	Timeout      time.Duration
+	// ReadOnly marks a command as safe to expose as a GraphQL Query
+	// field instead of a Mutation field. Set via WithReadOnly.
+	ReadOnly bool
+	// Deprecated, if not empty, is the reason shown alongside the
+	// GraphQL @deprecated directive for this command. Set via
+	// WithDeprecated.
+	Deprecated string
+	// AuthRole, if not empty, is the role required to invoke this
+	// command through the graphql package, which emits it as an @auth
+	// directive and checks it via its RoleChecker. authmw.Middleware
+	// does not read it. Set via WithAuthRole.
+	AuthRole string
+}
+
+// CommandOption configures a command added via AddCommand or
+// AddDefaultCommand, replacing the former bare resultsHandlers variadic
+// so that further options such as WithTimeout can be passed as well.
+type CommandOption func(*commandOptions)
+
+type commandOptions struct {
+	resultsHandlers []ResultsHandler
+	timeout         time.Duration
+	readOnly        bool
+	deprecated      string
+	authRole        string
+}
+
+func newCommandOptions(opts []CommandOption) commandOptions {
+	var options commandOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+// WithResultsHandlers sets the ResultsHandlers that previously were
+// passed directly as a trailing variadic to AddCommand.
+func WithResultsHandlers(resultsHandlers ...ResultsHandler) CommandOption {
+	return func(o *commandOptions) {
+		o.resultsHandlers = append(o.resultsHandlers, resultsHandlers...)
+	}
+}
+
+// WithTimeout sets the default timeout used to derive a context deadline
+// for the command, unless the caller of the command overrides it, e.g.
+// via the X-Request-Timeout header or timeout query param handled by
+// the gorillamux handlers.
+func WithTimeout(timeout time.Duration) CommandOption {
+	return func(o *commandOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WithReadOnly marks a command as read-only, so that generators such as
+// the graphql package expose it as a Query field instead of a Mutation
+// field.
+func WithReadOnly() CommandOption {
+	return func(o *commandOptions) {
+		o.readOnly = true
+	}
+}
+
+// WithDeprecated marks a command as deprecated with the given reason,
+// surfaced by generators such as the graphql package via a
+// @deprecated(reason: ...) directive.
+func WithDeprecated(reason string) CommandOption {
+	return func(o *commandOptions) {
+		o.deprecated = reason
+	}
+}
+
+// WithAuthRole requires role to invoke the command, surfaced by
+// generators such as the graphql package via a @auth(role: ...)
+// directive and checked by the graphql handler's RoleChecker. The
+// authmw middleware does not consult it.
+func WithAuthRole(role string) CommandOption {
+	return func(o *commandOptions) {
+		o.authRole = role
+	}
 }
 
 func checkCommandChars(command string) error {
@@ -65,14 +175,15 @@ func NewStringArgsDispatcher(loggers ...StringArgsCommandLogger) *StringArgsDisp
 	}
 }
 
-func (disp *StringArgsDispatcher) AddCommand(command, description string, commandFunc interface{}, args Args, resultsHandlers ...ResultsHandler) error {
+func (disp *StringArgsDispatcher) AddCommand(command, description string, commandFunc interface{}, args Args, opts ...CommandOption) error {
 	if _, exists := disp.comm[command]; exists {
 		return fmt.Errorf("Command '%s' already added", command)
 	}
 	if err := checkCommandChars(command); err != nil {
 		return fmt.Errorf("Command '%s' returned: %w", command, err)
 	}
-	stringArgsFunc, err := GetStringArgsFunc(commandFunc, args, resultsHandlers...)
+	options := newCommandOptions(opts)
+	stringArgsFunc, err := GetStringArgsFunc(commandFunc, args, options.resultsHandlers...)
 	if err != nil {
 		return fmt.Errorf("Command '%s' returned: %w", command, err)
 	}
@@ -82,20 +193,25 @@ func (disp *StringArgsDispatcher) AddCommand(command, description string, comman
 		args:            args,
 		commandFunc:     commandFunc,
 		stringArgsFunc:  stringArgsFunc,
-		resultsHandlers: resultsHandlers,
+		resultsHandlers: options.resultsHandlers,
+		timeout:         options.timeout,
+		readOnly:        options.readOnly,
+		deprecated:      options.deprecated,
+		authRole:        options.authRole,
 	}
 	return nil
 }
 
-func (disp *StringArgsDispatcher) MustAddCommand(command, description string, commandFunc interface{}, args Args, resultsHandlers ...ResultsHandler) {
-	err := disp.AddCommand(command, description, commandFunc, args, resultsHandlers...)
+func (disp *StringArgsDispatcher) MustAddCommand(command, description string, commandFunc interface{}, args Args, opts ...CommandOption) {
+	err := disp.AddCommand(command, description, commandFunc, args, opts...)
 	if err != nil {
 		panic(err)
 	}
 }
 
-func (disp *StringArgsDispatcher) AddDefaultCommand(description string, commandFunc interface{}, args Args, resultsHandlers ...ResultsHandler) error {
-	stringArgsFunc, err := GetStringArgsFunc(commandFunc, args, resultsHandlers...)
+func (disp *StringArgsDispatcher) AddDefaultCommand(description string, commandFunc interface{}, args Args, opts ...CommandOption) error {
+	options := newCommandOptions(opts)
+	stringArgsFunc, err := GetStringArgsFunc(commandFunc, args, options.resultsHandlers...)
 	if err != nil {
 		return fmt.Errorf("Default command: %w", err)
 	}
@@ -105,13 +221,17 @@ func (disp *StringArgsDispatcher) AddDefaultCommand(description string, commandF
 		args:            args,
 		commandFunc:     commandFunc,
 		stringArgsFunc:  stringArgsFunc,
-		resultsHandlers: resultsHandlers,
+		resultsHandlers: options.resultsHandlers,
+		timeout:         options.timeout,
+		readOnly:        options.readOnly,
+		deprecated:      options.deprecated,
+		authRole:        options.authRole,
 	}
 	return nil
 }
 
-func (disp *StringArgsDispatcher) MustAddDefaultCommand(description string, commandFunc interface{}, args Args, resultsHandlers ...ResultsHandler) {
-	err := disp.AddDefaultCommand(description, commandFunc, args, resultsHandlers...)
+func (disp *StringArgsDispatcher) MustAddDefaultCommand(description string, commandFunc interface{}, args Args, opts ...CommandOption) {
+	err := disp.AddDefaultCommand(description, commandFunc, args, opts...)
 	if err != nil {
 		panic(err)
 	}
@@ -127,6 +247,16 @@ func (disp *StringArgsDispatcher) HasDefaultCommnd() bool {
 	return found
 }
 
+// CommandTimeout returns the timeout set via WithTimeout for command, or
+// zero if no timeout was set or the command does not exist.
+func (disp *StringArgsDispatcher) CommandTimeout(command string) time.Duration {
+	cmd, found := disp.comm[command]
+	if !found {
+		return 0
+	}
+	return cmd.timeout
+}
+
 func (disp *StringArgsDispatcher) Dispatch(ctx context.Context, command string, args ...string) error {
 	cmd, found := disp.comm[command]
 	if !found {
@@ -135,6 +265,11 @@ func (disp *StringArgsDispatcher) Dispatch(ctx context.Context, command string,
 	for _, logger := range disp.loggers {
 		logger.LogStringArgsCommand(command, args)
 	}
+	if cmd.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cmd.timeout)
+		defer cancel()
+	}
 	return cmd.stringArgsFunc(ctx, args...)
 }
 
@@ -173,6 +308,17 @@ func (disp *StringArgsDispatcher) MustDispatchCombinedCommandAndArgs(ctx context
 	return command
 }
 
+// WalkCommands calls walkFunc with the CommandInfo of every command
+// added to the dispatcher, including the default command if one was
+// added. It allows generators such as the openapi, admin and graphql
+// packages to inspect registered commands without touching the
+// dispatcher's internal map.
+func (disp *StringArgsDispatcher) WalkCommands(walkFunc func(CommandInfo)) {
+	for _, cmd := range disp.comm {
+		walkFunc(cmd.info(Default))
+	}
+}
+
 func (disp *StringArgsDispatcher) PrintCommands(appName string) {
 	list := make([]*stringArgsCommand, 0, len(disp.comm))
 	for _, cmd := range disp.comm {
@@ -47,16 +47,16 @@ func (disp *SuperStringArgsDispatcher) MustAddSuperCommand(superCommand string)
 	return subDisp
 }
 
-func (disp *SuperStringArgsDispatcher) AddDefaultCommand(description string, commandFunc interface{}, args Args, resultsHandlers ...ResultsHandler) error {
+func (disp *SuperStringArgsDispatcher) AddDefaultCommand(description string, commandFunc interface{}, args Args, opts ...CommandOption) error {
 	subDisp, err := disp.AddSuperCommand(Default)
 	if err != nil {
 		return err
 	}
-	return subDisp.AddDefaultCommand(description, commandFunc, args, resultsHandlers...)
+	return subDisp.AddDefaultCommand(description, commandFunc, args, opts...)
 }
 
-func (disp *SuperStringArgsDispatcher) MustAddDefaultCommand(description string, commandFunc interface{}, args Args, resultsHandlers ...ResultsHandler) {
-	err := disp.AddDefaultCommand(description, commandFunc, args, resultsHandlers...)
+func (disp *SuperStringArgsDispatcher) MustAddDefaultCommand(description string, commandFunc interface{}, args Args, opts ...CommandOption) {
+	err := disp.AddDefaultCommand(description, commandFunc, args, opts...)
 	if err != nil {
 		panic(fmt.Errorf("MustAddDefaultCommand(%s): %w", description, err))
 	}
@@ -134,6 +134,18 @@ func (disp *SuperStringArgsDispatcher) MustDispatchCombinedCommandAndArgs(ctx co
 	return superCommand, command
 }
 
+// WalkCommands calls walkFunc with the CommandInfo of every command of
+// every super command added to the dispatcher. It allows generators
+// such as the openapi, admin and graphql packages to inspect registered
+// commands without touching the dispatcher's internal maps.
+func (disp *SuperStringArgsDispatcher) WalkCommands(walkFunc func(CommandInfo)) {
+	for super, sub := range disp.sub {
+		for _, cmd := range sub.comm {
+			walkFunc(cmd.info(super))
+		}
+	}
+}
+
 func (disp *SuperStringArgsDispatcher) PrintCommands(appName string) {
 	type superCmd struct {
 		super string